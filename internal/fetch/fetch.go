@@ -0,0 +1,141 @@
+// Package fetch expands an image/album/gallery URL pasted into chat into
+// the list of direct image URLs it refers to, so the bot can turn each
+// one into a Game of Life board.
+package fetch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// maxAlbumSize caps how many images a single album/gallery link expands
+// to, so one link can't be used to make the bot fetch an unbounded number
+// of images.
+const maxAlbumSize = 50
+
+// ErrUnsafeHost is returned by CheckHost when a URL points at a private,
+// loopback, or otherwise internal-only address.
+var ErrUnsafeHost = errors.New("refusing to fetch a private or internal host")
+
+// CheckHost rejects rawUrl unless it is plain http(s) and every address
+// its host resolves to is a public, routable address. Callers should run
+// this before fetching a URL that came from an untrusted chat message, to
+// avoid turning the bot into an SSRF proxy against internal services.
+func CheckHost(rawUrl string) error {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return err
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", parsedUrl.Scheme)
+	}
+
+	ips, err := net.LookupIP(parsedUrl.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, ip := range ips {
+		if isUnsafeIP(ip) {
+			return fmt.Errorf("%w: %s", ErrUnsafeHost, ip)
+		}
+	}
+	return nil
+}
+
+func isUnsafeIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() ||
+		ip.IsMulticast()
+}
+
+// SplitExt splits p into its base path and extension (including the
+// leading dot, e.g. ".png").
+func SplitExt(p string) (string, string) {
+	ext := path.Ext(p)
+	return strings.TrimSuffix(p, ext), ext
+}
+
+// Expand takes a URL a user pasted into chat and returns the direct
+// image/video URLs it refers to. Recognized album/gallery links are
+// expanded to every image they contain; anything else is returned as a
+// single-element slice pointing at the original URL.
+func Expand(rawUrl string) ([]string, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parsedUrl.Hostname() {
+	case "imgur.com", "i.imgur.com":
+		if id, ok := imgurAlbumID(parsedUrl.Path); ok {
+			return expandImgurAlbum(id)
+		}
+	}
+
+	return []string{rawUrl}, nil
+}
+
+func imgurAlbumID(urlPath string) (string, bool) {
+	for _, prefix := range []string{"/a/", "/gallery/"} {
+		if strings.HasPrefix(urlPath, prefix) {
+			return strings.TrimPrefix(urlPath, prefix), true
+		}
+	}
+	return "", false
+}
+
+type imgurAlbumResponse struct {
+	Data struct {
+		Images []struct {
+			ID  string `json:"id"`
+			Ext string `json:"ext"`
+		} `json:"images"`
+	} `json:"data"`
+}
+
+// expandImgurAlbum calls the Imgur album API to enumerate every image
+// hash+ext in the album, rewriting .gifv links to .mp4 since that's the
+// actual video file Imgur serves behind a .gifv page.
+func expandImgurAlbum(id string) ([]string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.imgur.com/3/album/%s/images", id), nil)
+	if err != nil {
+		return nil, err
+	}
+	if clientID := os.Getenv("IMGUR_CLIENT_ID"); clientID != "" {
+		req.Header.Set("Authorization", "Client-ID "+clientID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var parsed imgurAlbumResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(parsed.Data.Images))
+	for _, img := range parsed.Data.Images {
+		ext := img.Ext
+		if ext == ".gifv" {
+			ext = ".mp4"
+		}
+		urls = append(urls, fmt.Sprintf("https://i.imgur.com/%s%s", img.ID, ext))
+		if len(urls) >= maxAlbumSize {
+			break
+		}
+	}
+	return urls, nil
+}
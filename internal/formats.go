@@ -0,0 +1,292 @@
+package internal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LoadFromRLE parses the Life pattern-exchange RLE format: a header line
+// "x = W, y = H, rule = B3/S23" followed by run-length encoded rows using
+// the tokens "<count>b" (dead), "<count>o" (alive) and "<count>$" (end of
+// row), terminated by "!". Lines starting with "#" (comments such as
+// #N/#C/#P) are skipped.
+func LoadFromRLE(data string) (*GameOfLife, error) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	width, height := 0, 0
+	rule := DefaultRule()
+	headerSeen := false
+	var body strings.Builder
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !headerSeen {
+			w, h, r, err := parseRLEHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			width, height, rule = w, h, r
+			headerSeen = true
+			continue
+		}
+		body.WriteString(line)
+	}
+	if !headerSeen {
+		return nil, errors.New("missing RLE header")
+	}
+
+	game, err := NewGameOfLifeWithRule(width, height, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	x, y, count := 0, 0, 0
+	for _, ch := range body.String() {
+		switch {
+		case ch >= '0' && ch <= '9':
+			count = count*10 + int(ch-'0')
+
+		case ch == 'b' || ch == 'o':
+			n := count
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				if x >= width {
+					x = 0
+					y++
+				}
+				if ch == 'o' {
+					game.Set(x, y, true)
+				}
+				x++
+			}
+			count = 0
+
+		case ch == '$':
+			n := count
+			if n == 0 {
+				n = 1
+			}
+			y += n
+			x = 0
+			count = 0
+
+		case ch == '!':
+			return game, nil
+		}
+	}
+
+	return game, nil
+}
+
+// parseRLEHeader parses the "x = W, y = H, rule = ..." header line,
+// defaulting to Conway's B3/S23 when no rule field is present.
+func parseRLEHeader(line string) (int, int, Rule, error) {
+	width, height := 0, 0
+	rule := DefaultRule()
+
+	for _, field := range strings.Split(line, ",") {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, val := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+		var err error
+		switch key {
+		case "x":
+			width, err = strconv.Atoi(val)
+		case "y":
+			height, err = strconv.Atoi(val)
+		case "rule":
+			rule, err = ParseRule(val)
+		}
+		if err != nil {
+			return 0, 0, Rule{}, fmt.Errorf("invalid RLE header %q: %w", line, err)
+		}
+	}
+
+	if width <= 0 || height <= 0 {
+		return 0, 0, Rule{}, fmt.Errorf("invalid RLE header %q: missing x/y", line)
+	}
+	return width, height, rule, nil
+}
+
+// LoadFromLife106 parses the Life 1.06 format: a "#Life 1.06" header
+// followed by one "x y" coordinate pair per line for each live cell.
+// Coordinates may be negative, so the bounding box of all points is
+// computed first and used to size the resulting GameOfLife.
+func LoadFromLife106(data string) (*GameOfLife, error) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	type point struct{ x, y int }
+	var points []point
+	minX, minY, maxX, maxY := 0, 0, 0, 0
+	headerSeen, first := false, true
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !headerSeen {
+			if !strings.HasPrefix(line, "#Life 1.06") {
+				return nil, errors.New("missing #Life 1.06 header")
+			}
+			headerSeen = true
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed Life 1.06 coordinate line: %q", line)
+		}
+		x, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		y, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point{x, y})
+
+		if first {
+			minX, maxX, minY, maxY = x, x, y, y
+			first = false
+		} else {
+			minX, maxX = min(minX, x), max(maxX, x)
+			minY, maxY = min(minY, y), max(maxY, y)
+		}
+	}
+	if !headerSeen {
+		return nil, errors.New("missing #Life 1.06 header")
+	}
+
+	game, err := NewGameOfLife(maxX-minX+1, maxY-minY+1)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range points {
+		game.Set(p.x-minX, p.y-minY, true)
+	}
+	return game, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// LoadFromCells parses the plain text ".cells" format: "!"-prefixed
+// comment lines followed by rows of "." (dead) and "O" (alive).
+func LoadFromCells(data string) (*GameOfLife, error) {
+	var rows []string
+	width := 0
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+		rows = append(rows, line)
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("empty .cells pattern")
+	}
+
+	game, err := NewGameOfLife(width, len(rows))
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		for j, ch := range row {
+			if ch == 'O' {
+				game.Set(j, i, true)
+			}
+		}
+	}
+	return game, nil
+}
+
+// EncodeRLE serializes the game state as an RLE pattern: the standard
+// "x = W, y = H, rule = B3/S23" header followed by run-length encoded
+// rows using the b/o/$ tokens and a trailing "!".
+func (g *GameOfLife) EncodeRLE() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "x = %d, y = %d, rule = %s\n", g.width, g.height, g.rule.String())
+
+	col := 0
+	emit := func(n int, tag byte) {
+		token := string(tag)
+		if n > 1 {
+			token = fmt.Sprintf("%d%c", n, tag)
+		}
+		if col+len(token) > 70 {
+			buf.WriteString("\n")
+			col = 0
+		}
+		buf.WriteString(token)
+		col += len(token)
+	}
+
+	for i := 0; i < g.height; i++ {
+		runAlive, runLen := g.At(0, i), 0
+		for j := 0; j < g.width; j++ {
+			alive := g.At(j, i)
+			if j > 0 && alive != runAlive {
+				emit(runLen, rleTag(runAlive))
+				runAlive, runLen = alive, 0
+			}
+			runLen++
+		}
+		emit(runLen, rleTag(runAlive))
+		if i < g.height-1 {
+			emit(1, '$')
+		}
+	}
+	buf.WriteString("!\n")
+
+	return buf.String()
+}
+
+func rleTag(alive bool) byte {
+	if alive {
+		return 'o'
+	}
+	return 'b'
+}
+
+// EncodeLife106 serializes every living cell as an "x y" coordinate line,
+// preceded by the "#Life 1.06" header.
+func (g *GameOfLife) EncodeLife106() string {
+	var buf strings.Builder
+	buf.WriteString("#Life 1.06\n")
+	for i := 0; i < g.height; i++ {
+		for j := 0; j < g.width; j++ {
+			if g.At(j, i) {
+				fmt.Fprintf(&buf, "%d %d\n", j, i)
+			}
+		}
+	}
+	return buf.String()
+}
@@ -17,10 +17,17 @@ type GameOfLife struct {
 	width, height int
 	data          [][]bool // current state
 	back_buffer   [][]bool // future state
+	rule          Rule
 }
 
 func NewGameOfLife(width, height int) (*GameOfLife, error) {
-	game := GameOfLife{width, height, nil, nil}
+	return NewGameOfLifeWithRule(width, height, DefaultRule())
+}
+
+// NewGameOfLifeWithRule is NewGameOfLife with an explicit birth/survival
+// rule instead of Conway's default B3/S23.
+func NewGameOfLifeWithRule(width, height int, r Rule) (*GameOfLife, error) {
+	game := GameOfLife{width, height, nil, nil, r}
 	if width <= 0 || height <= 0 {
 		return nil, errors.New("invalid size")
 	}
@@ -198,21 +205,30 @@ func (g *GameOfLife) Update() {
 	for i := 0; i < g.height; i++ {
 		for j := 0; j < g.width; j++ {
 			cnt := g.CellValue(j, i)
-			if g.At(j, i) {
-				if cnt < 2 || cnt > 3 {
-					g.back_buffer[i][j] = false
-				}
+			alive := g.At(j, i)
+			if alive {
+				alive = g.rule.survive&(1<<uint(cnt)) != 0
 			} else {
-				if cnt == 3 {
-					g.back_buffer[i][j] = true
-				}
+				alive = g.rule.birth&(1<<uint(cnt)) != 0
 			}
+			g.back_buffer[i][j] = alive
 		}
 	}
 
 	g.Flush()
 }
 
+// Rule returns the game's active birth/survival rule.
+func (g *GameOfLife) Rule() Rule {
+	return g.rule
+}
+
+// SetRule switches the game's active birth/survival rule, effective on
+// the next Update.
+func (g *GameOfLife) SetRule(r Rule) {
+	g.rule = r
+}
+
 func (g *GameOfLife) GetWidth() int {
 	return g.width
 }
@@ -0,0 +1,154 @@
+package live
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"gameoflife/internal"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// Hub is the registry of active rooms, keyed by room ID. It is safe for
+// concurrent use from multiple HTTP handler goroutines.
+type Hub struct {
+	mu    sync.Mutex
+	rooms map[string]*Room
+}
+
+// NewHub creates an empty room registry.
+func NewHub() *Hub {
+	return &Hub{rooms: make(map[string]*Room)}
+}
+
+// Room returns the room for id, creating one from fresh with the given
+// dimensions if it does not exist yet.
+func (h *Hub) Room(id string, width, height int) (*Room, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if room, ok := h.rooms[id]; ok {
+		return room, nil
+	}
+
+	game, err := internal.NewGameOfLife(width, height)
+	if err != nil {
+		return nil, err
+	}
+	return h.seedLocked(id, game), nil
+}
+
+// Seed registers game as room id, replacing any existing room with the
+// same ID, and starts its goroutine. Use this to make a freshly parsed
+// pattern (e.g. from a Telegram upload) co-editable instead of replying
+// with a one-shot GIF.
+func (h *Hub) Seed(id string, game *internal.GameOfLife) *Room {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.seedLocked(id, game)
+}
+
+func (h *Hub) seedLocked(id string, game *internal.GameOfLife) *Room {
+	if old, ok := h.rooms[id]; ok {
+		old.Stop()
+	}
+
+	room := NewRoom(id, game)
+	h.rooms[id] = room
+	go room.Run()
+	return room
+}
+
+// ServeWS upgrades an HTTP request to a websocket connection and attaches
+// it to the room named by the "roomID" path element (everything in
+// r.URL.Path after "/ws/"), creating a default-sized room if it doesn't
+// exist.
+func (h *Hub) ServeWS(w http.ResponseWriter, r *http.Request) {
+	roomID := strings.TrimPrefix(r.URL.Path, "/ws/")
+	if roomID == "" {
+		http.Error(w, "missing room id", http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.Room(roomID, 64, 64)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("live: upgrade failed: %v", err)
+		return
+	}
+
+	room.Join(conn)
+	defer room.Leave(conn)
+
+	for {
+		_, payload, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+
+		var cmd Command
+		if err := json.Unmarshal(payload, &cmd); err != nil {
+			log.Printf("live: room %s: dropping malformed command: %v", roomID, err)
+			continue
+		}
+		room.Enqueue(cmd)
+	}
+}
+
+// ServeIndex serves a small static page that connects to /ws/{roomID} and
+// renders the board on a <canvas>, letting anyone with the link co-edit it.
+func ServeIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(indexHTML))
+}
+
+const indexHTML = `<!DOCTYPE html>
+<html>
+<head><title>Game of Life</title></head>
+<body>
+<canvas id="board" width="640" height="640" style="background:#fff"></canvas>
+<script>
+const room = location.pathname.split("/").pop() || "default";
+const ws = new WebSocket((location.protocol === "https:" ? "wss://" : "ws://") + location.host + "/ws/" + room);
+const canvas = document.getElementById("board");
+const ctx = canvas.getContext("2d");
+
+ws.onmessage = (ev) => {
+	const state = JSON.parse(ev.data);
+	const sx = canvas.width / state.width;
+	const sy = canvas.height / state.height;
+	ctx.clearRect(0, 0, canvas.width, canvas.height);
+	ctx.fillStyle = "#000";
+	for (let y = 0; y < state.height; y++) {
+		for (let x = 0; x < state.width; x++) {
+			if (state.cells[y][x]) {
+				ctx.fillRect(x * sx, y * sy, sx, sy);
+			}
+		}
+	}
+};
+
+canvas.onclick = (ev) => {
+	const rect = canvas.getBoundingClientRect();
+	const x = Math.floor((ev.clientX - rect.left) / canvas.width * 64);
+	const y = Math.floor((ev.clientY - rect.top) / canvas.height * 64);
+	ws.send(JSON.stringify({type: "toggle", x: x, y: y}));
+};
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,209 @@
+// Package live exposes a running internal.GameOfLife instance over
+// websockets so multiple clients can watch and co-edit the same board in
+// real time, mirroring the paint-board Server2.go pattern of a single
+// goroutine owning shared state behind add/del channels and a command
+// queue; that goroutine writes broadcasts to subscribers directly.
+package live
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"gameoflife/internal"
+
+	"github.com/gorilla/websocket"
+)
+
+// Command is a typed message sent by a client over its websocket
+// connection to mutate the room's game.
+type Command struct {
+	Type  string `json:"type"`
+	X     int    `json:"x,omitempty"`
+	Y     int    `json:"y,omitempty"`
+	RLE   string `json:"rle,omitempty"`
+	Speed int    `json:"speed,omitempty"`
+}
+
+// state is what gets marshaled to every subscriber after each tick or
+// applied command.
+type state struct {
+	Width  int      `json:"width"`
+	Height int      `json:"height"`
+	Cells  [][]bool `json:"cells"`
+}
+
+// Room owns a single GameOfLife instance. A single goroutine (Run) owns
+// the game state; connections are only ever added, removed or sent to
+// from that goroutine, so the game itself needs no locking of its own.
+type Room struct {
+	ID   string
+	game *internal.GameOfLife
+
+	tickInterval time.Duration
+
+	addCh       chan *websocket.Conn
+	delCh       chan *websocket.Conn
+	cmdCh       chan Command
+	broadcastCh chan []byte
+	stopCh      chan struct{}
+
+	conns map[*websocket.Conn]bool
+}
+
+// NewRoom creates a room wrapping game, ticking at the default speed
+// until a client sends a set-speed command.
+func NewRoom(id string, game *internal.GameOfLife) *Room {
+	return &Room{
+		ID:           id,
+		game:         game,
+		tickInterval: 200 * time.Millisecond,
+		addCh:        make(chan *websocket.Conn),
+		delCh:        make(chan *websocket.Conn),
+		cmdCh:        make(chan Command, 16),
+		broadcastCh:  make(chan []byte, 16),
+		stopCh:       make(chan struct{}),
+		conns:        make(map[*websocket.Conn]bool),
+	}
+}
+
+// Run drives the room's game loop and subscriber bookkeeping. It must be
+// started in its own goroutine and is the only goroutine that touches
+// r.game or r.conns. Run returns, closing any remaining subscribers, once
+// Stop is called (e.g. because the room was replaced in the Hub).
+func (r *Room) Run() {
+	ticker := time.NewTicker(r.tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			for conn := range r.conns {
+				delete(r.conns, conn)
+				conn.Close()
+			}
+			return
+
+		case conn := <-r.addCh:
+			r.conns[conn] = true
+			r.sendTo(conn, r.encodeState())
+
+		case conn := <-r.delCh:
+			if _, ok := r.conns[conn]; ok {
+				delete(r.conns, conn)
+				conn.Close()
+			}
+
+		case cmd := <-r.cmdCh:
+			if interval, ok := r.apply(cmd); ok {
+				ticker.Reset(interval)
+			}
+			r.broadcast(r.encodeState())
+
+		case <-ticker.C:
+			r.game.Update()
+			r.broadcast(r.encodeState())
+		}
+	}
+}
+
+// Stop signals the room's Run goroutine to close all subscribers and
+// exit. Safe to call at most once per room.
+func (r *Room) Stop() {
+	close(r.stopCh)
+}
+
+// apply mutates the game according to cmd. It returns a new tick interval
+// and true when cmd was a set-speed command with a positive speed.
+func (r *Room) apply(cmd Command) (time.Duration, bool) {
+	switch cmd.Type {
+	case "toggle":
+		r.game.Set(cmd.X, cmd.Y, !r.game.At(cmd.X, cmd.Y))
+
+	case "clear":
+		for y := 0; y < r.game.GetHeight(); y++ {
+			for x := 0; x < r.game.GetWidth(); x++ {
+				r.game.Set(x, y, false)
+			}
+		}
+
+	case "load-rle":
+		loaded, err := internal.LoadFromRLE(cmd.RLE)
+		if err != nil {
+			log.Printf("live: room %s: bad load-rle command: %v", r.ID, err)
+			return 0, false
+		}
+		r.game = loaded
+
+	case "set-speed":
+		if cmd.Speed <= 0 {
+			return 0, false
+		}
+		return time.Duration(cmd.Speed) * time.Millisecond, true
+	}
+
+	return 0, false
+}
+
+func (r *Room) encodeState() []byte {
+	payload, err := json.Marshal(state{
+		Width:  r.game.GetWidth(),
+		Height: r.game.GetHeight(),
+		Cells:  r.game.GetState(),
+	})
+	if err != nil {
+		log.Printf("live: room %s: failed to marshal state: %v", r.ID, err)
+		return nil
+	}
+	return payload
+}
+
+// broadcast writes payload to every subscriber, dropping any connection
+// whose write fails. It runs on the room's own goroutine, so failed
+// connections are removed from r.conns directly instead of routing
+// through delCh, which only that same goroutine reads and so cannot drain
+// while broadcast is running.
+func (r *Room) broadcast(payload []byte) {
+	if payload == nil {
+		return
+	}
+
+	var dead []*websocket.Conn
+	for conn := range r.conns {
+		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+			log.Printf("live: room %s: write failed, dropping subscriber: %v", r.ID, err)
+			dead = append(dead, conn)
+		}
+	}
+
+	for _, conn := range dead {
+		delete(r.conns, conn)
+		conn.Close()
+	}
+}
+
+func (r *Room) sendTo(conn *websocket.Conn, payload []byte) {
+	if payload == nil {
+		return
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+		log.Printf("live: room %s: write failed, dropping subscriber: %v", r.ID, err)
+		delete(r.conns, conn)
+		conn.Close()
+	}
+}
+
+// Join registers conn as a subscriber of the room.
+func (r *Room) Join(conn *websocket.Conn) {
+	r.addCh <- conn
+}
+
+// Leave unregisters conn from the room.
+func (r *Room) Leave(conn *websocket.Conn) {
+	r.delCh <- conn
+}
+
+// Enqueue queues a client command for the room's goroutine to apply.
+func (r *Room) Enqueue(cmd Command) {
+	r.cmdCh <- cmd
+}
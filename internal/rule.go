@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rule is a birth/survival rule in B/S notation (e.g. "B3/S23"), stored
+// as two bitmasks over neighbor counts 0..8: bit n is set when n live
+// neighbors triggers a birth (or survival).
+type Rule struct {
+	birth, survive uint16
+}
+
+// DefaultRule is Conway's original B3/S23.
+func DefaultRule() Rule {
+	r, _ := ParseRule("B3/S23")
+	return r
+}
+
+// ParseRule parses a rulestring such as "B3/S23" (Conway), "B36/S23"
+// (HighLife), "B2/S" or "B3/S12345" (Maze).
+func ParseRule(s string) (Rule, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rulestring %q", s)
+	}
+
+	bPart, sPart := parts[0], parts[1]
+	if !strings.HasPrefix(strings.ToUpper(bPart), "B") || !strings.HasPrefix(strings.ToUpper(sPart), "S") {
+		return Rule{}, fmt.Errorf("invalid rulestring %q", s)
+	}
+
+	birth, err := parseNeighborCounts(bPart[1:])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rulestring %q: %w", s, err)
+	}
+	survive, err := parseNeighborCounts(sPart[1:])
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rulestring %q: %w", s, err)
+	}
+
+	return Rule{birth: birth, survive: survive}, nil
+}
+
+func parseNeighborCounts(digits string) (uint16, error) {
+	var mask uint16
+	for _, ch := range digits {
+		n, err := strconv.Atoi(string(ch))
+		if err != nil || n < 0 || n > 8 {
+			return 0, fmt.Errorf("invalid neighbor count %q", string(ch))
+		}
+		mask |= 1 << uint(n)
+	}
+	return mask, nil
+}
+
+// String renders r back to B/S notation.
+func (r Rule) String() string {
+	return fmt.Sprintf("B%s/S%s", neighborCountsString(r.birth), neighborCountsString(r.survive))
+}
+
+func neighborCountsString(mask uint16) string {
+	var buf strings.Builder
+	for n := 0; n <= 8; n++ {
+		if mask&(1<<uint(n)) != 0 {
+			buf.WriteString(strconv.Itoa(n))
+		}
+	}
+	return buf.String()
+}
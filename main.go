@@ -10,11 +10,14 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"gameoflife/internal"
+	"gameoflife/internal/live"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	"github.com/joho/godotenv"
@@ -25,6 +28,10 @@ const (
 	MaxIterations    = 2048
 )
 
+// liveHub is non-nil when LIVE_ENABLED is "1", letting handlers seed a
+// co-editable room instead of always replying with a one-shot GIF.
+var liveHub *live.Hub
+
 func TerminalUpdate(game *internal.GameOfLife) {
 	for {
 		fmt.Println(game.Text())
@@ -82,6 +89,35 @@ func GenerateGIF(game *internal.GameOfLife, output io.Writer) {
 	})
 }
 
+// startLiveServer wires the websocket viewer behind a config flag so it
+// can run alongside the Telegram bot without interfering with it.
+func startLiveServer(listenAddr string) *live.Hub {
+	hub := live.NewHub()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/", hub.ServeWS)
+	mux.HandleFunc("/", live.ServeIndex)
+
+	go func() {
+		if err := http.ListenAndServe(listenAddr, mux); err != nil {
+			fmt.Printf("live server stopped: %v\n", err)
+		}
+	}()
+
+	return hub
+}
+
+// seedLiveRoom registers gol as a co-editable room keyed by chatID and
+// replies with its viewer link, for use in place of a one-shot GIF.
+func seedLiveRoom(gol *internal.GameOfLife, chatID int64, update tgbotapi.Update, bot *tgbotapi.BotAPI) {
+	roomID := fmt.Sprintf("%d", chatID)
+	liveHub.Seed(roomID, gol)
+
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Live room ready: %s/%s", os.Getenv("LIVE_PUBLIC_URL"), roomID))
+	msg.ReplyToMessageID = update.Message.MessageID
+	bot.Send(msg)
+}
+
 func PhotoReceivedHandler(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
 	req := fmt.Sprintf(GetFileUrl, bot.Token, update.Message.Photo[0].FileID)
 	resp, err := http.Get(req)
@@ -131,6 +167,12 @@ func PhotoReceivedHandler(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
 	bot.Send(msg)
 
 	gol, err := internal.LoadFromJPEG(image_path)
+	applyChatRule(gol, update.Message.Chat.ID)
+
+	if liveHub != nil && update.Message.Caption == "/live" {
+		seedLiveRoom(gol, update.Message.Chat.ID, update, bot)
+		return
+	}
 
 	var buffer bytes.Buffer
 	GenerateGIF(gol, &buffer)
@@ -140,6 +182,7 @@ func PhotoReceivedHandler(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
 		Bytes: buffer.Bytes(),
 	}
 	anim := tgbotapi.NewAnimation(update.Message.Chat.ID, final_result)
+	anim.Caption = fmt.Sprintf("rule: %s", gol.Rule().String())
 	anim.ReplyToMessageID = update.Message.MessageID
 	_, err = bot.Send(anim)
 	if err != nil {
@@ -151,13 +194,30 @@ func PhotoReceivedHandler(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
 func TextReceivedHandler(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
 	data := update.Message.Text
 
-	gol, err := internal.LoadFromText(data, 'o')
+	if parsedUrl, err := url.Parse(data); err == nil && (parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https") && parsedUrl.Host != "" {
+		handleURLText(update, bot, data)
+		return
+	}
+
+	seedLive := false
+	if liveHub != nil && strings.HasPrefix(data, "/live") {
+		seedLive = true
+		data = strings.TrimSpace(strings.TrimPrefix(data, "/live"))
+	}
+
+	gol, err := loadFromPattern(data)
 	if err != nil {
 		msg := tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("error while parsing text: %v\n", err))
 		msg.ReplyToMessageID = update.Message.MessageID
 		bot.Send(msg)
 		return
 	}
+	applyChatRule(gol, update.Message.Chat.ID)
+
+	if seedLive {
+		seedLiveRoom(gol, update.Message.Chat.ID, update, bot)
+		return
+	}
 
 	var buffer bytes.Buffer
 	GenerateGIF(gol, &buffer)
@@ -167,6 +227,7 @@ func TextReceivedHandler(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
 		Bytes: buffer.Bytes(),
 	}
 	anim := tgbotapi.NewAnimation(update.Message.Chat.ID, final_result)
+	anim.Caption = fmt.Sprintf("rule: %s", gol.Rule().String())
 	anim.ReplyToMessageID = update.Message.MessageID
 	_, err = bot.Send(anim)
 	if err != nil {
@@ -198,19 +259,31 @@ func init() {
 }
 
 func main() {
+	if os.Getenv("LIVE_ENABLED") == "1" {
+		listenAddr := os.Getenv("LIVE_LISTEN")
+		if listenAddr == "" {
+			listenAddr = ":8080"
+		}
+		liveHub = startLiveServer(listenAddr)
+		fmt.Printf("live viewer listening on %s\n", listenAddr)
+	}
+
 	bot, err := tgbotapi.NewBotAPI(os.Getenv("BOT_API_TOKEN"))
 	if err != nil {
 		fmt.Printf("Failed to init bot: %v", err)
 		return
 	}
 
-	u := tgbotapi.NewUpdate(0)
-	u.Timeout = 60
-
-	updates := bot.GetUpdatesChan(u)
+	updates, err := transportFromEnv().Start(bot)
+	if err != nil {
+		fmt.Printf("Failed to start bot transport: %v", err)
+		return
+	}
 
 	for update := range updates {
-		if len(update.Message.Photo) > 0 {
+		if update.Message != nil && strings.HasPrefix(update.Message.Text, "/rule") {
+			go handleRuleCommand(update, bot)
+		} else if len(update.Message.Photo) > 0 {
 			go PhotoReceivedHandler(update, bot)
 		} else if update.Message != nil {
 			go TextReceivedHandler(update, bot)
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+
+	"gameoflife/internal"
+)
+
+// loadFromPattern sniffs data to pick between the Life community's common
+// pattern formats, falling back to this module's own ad-hoc text format
+// when nothing else matches. Archive-style RLE pastes usually lead with
+// one or more "#N"/"#C"/"#P" comment lines before the "x = ..." header, so
+// the RLE check looks past those rather than only at the very first line.
+func loadFromPattern(data string) (*internal.GameOfLife, error) {
+	firstLine := ""
+	firstNonCommentLine := ""
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if firstLine == "" {
+			firstLine = line
+		}
+		if firstNonCommentLine == "" && !strings.HasPrefix(line, "#") {
+			firstNonCommentLine = line
+		}
+		if firstLine != "" && firstNonCommentLine != "" {
+			break
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(firstLine, "#Life 1.06"):
+		return internal.LoadFromLife106(data)
+	case strings.HasPrefix(firstNonCommentLine, "x") && strings.Contains(firstNonCommentLine, "="):
+		return internal.LoadFromRLE(data)
+	case strings.HasPrefix(firstLine, "!"):
+		return internal.LoadFromCells(data)
+	default:
+		return internal.LoadFromText(data, 'o')
+	}
+}
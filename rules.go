@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gameoflife/internal"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// chatRules remembers the rule each chat picked via /rule, applied to
+// every board that chat generates from then on.
+var chatRules sync.Map // map[int64]internal.Rule
+
+// applyChatRule overrides gol's rule with the one the chat picked via
+// /rule, if any. A pattern format that carries its own rule (such as RLE's
+// "rule = ..." header) is left alone until the user opts in explicitly.
+func applyChatRule(gol *internal.GameOfLife, chatID int64) {
+	if v, ok := chatRules.Load(chatID); ok {
+		gol.SetRule(v.(internal.Rule))
+	}
+}
+
+// handleRuleCommand parses a "/rule B36/S23" message and stores the rule
+// for the sending chat, replying with confirmation or a parse error.
+func handleRuleCommand(update tgbotapi.Update, bot *tgbotapi.BotAPI) {
+	arg := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/rule"))
+
+	rule, err := internal.ParseRule(arg)
+	if err != nil {
+		msg := tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("error while parsing rule: %v\n", err))
+		msg.ReplyToMessageID = update.Message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	chatRules.Store(update.Message.Chat.ID, rule)
+
+	msg := tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("rule set to %s for the next generation", rule.String()))
+	msg.ReplyToMessageID = update.Message.MessageID
+	bot.Send(msg)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// BotTransport decides how update.Message updates are delivered from
+// Telegram, so main's dispatch loop doesn't care whether they arrive via
+// long polling or an HTTPS webhook.
+type BotTransport interface {
+	Start(bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, error)
+}
+
+// PollingTransport is the long-polling behavior GetUpdatesChan already
+// provided.
+type PollingTransport struct {
+	Timeout int
+}
+
+func (t PollingTransport) Start(bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, error) {
+	u := tgbotapi.NewUpdate(0)
+	u.Timeout = t.Timeout
+	return bot.GetUpdatesChan(u), nil
+}
+
+// WebhookTransport registers an HTTPS webhook with Telegram and serves it
+// directly, avoiding the outbound long-poll round trip entirely. This
+// matters when GenerateGIF can block a handler for many seconds, since a
+// webhook push doesn't hold a long-poll connection open while that runs.
+type WebhookTransport struct {
+	URL      string
+	CertPath string
+	KeyPath  string
+	Listen   string
+}
+
+func (t WebhookTransport) Start(bot *tgbotapi.BotAPI) (tgbotapi.UpdatesChannel, error) {
+	wh, err := tgbotapi.NewWebhookWithCert(t.URL, tgbotapi.FilePath(t.CertPath))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := bot.Request(wh)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Ok {
+		return nil, fmt.Errorf("failed to set webhook: %s", resp.Description)
+	}
+
+	updates := bot.ListenForWebhook("/" + bot.Token)
+	go func() {
+		if err := http.ListenAndServeTLS(t.Listen, t.CertPath, t.KeyPath, nil); err != nil {
+			fmt.Printf("webhook server stopped: %v\n", err)
+		}
+	}()
+
+	return updates, nil
+}
+
+// transportFromEnv picks the bot transport based on BOT_MODE
+// ("webhook"|"polling", default "polling") and its related
+// BOT_WEBHOOK_URL / BOT_CERT / BOT_KEY / BOT_LISTEN env vars.
+func transportFromEnv() BotTransport {
+	if os.Getenv("BOT_MODE") != "webhook" {
+		return PollingTransport{Timeout: 60}
+	}
+
+	listen := os.Getenv("BOT_LISTEN")
+	if listen == "" {
+		listen = ":8443"
+	}
+
+	return WebhookTransport{
+		URL:      os.Getenv("BOT_WEBHOOK_URL"),
+		CertPath: os.Getenv("BOT_CERT"),
+		KeyPath:  os.Getenv("BOT_KEY"),
+		Listen:   listen,
+	}
+}
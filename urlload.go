@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gameoflife/internal"
+	"gameoflife/internal/fetch"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// loaderFor is a GameOfLife loader bound to a local file path, picked by
+// extension or Content-Type.
+type loaderFor func(path string) (*internal.GameOfLife, error)
+
+func loaderForExt(ext string) loaderFor {
+	switch strings.ToLower(ext) {
+	case ".png":
+		return internal.LoadFromPNG
+	case ".jpg", ".jpeg":
+		return internal.LoadFromJPEG
+	}
+	return nil
+}
+
+func loaderForContentType(contentType string) loaderFor {
+	switch {
+	case strings.Contains(contentType, "png"):
+		return internal.LoadFromPNG
+	case strings.Contains(contentType, "jpeg"), strings.Contains(contentType, "jpg"):
+		return internal.LoadFromJPEG
+	}
+	return nil
+}
+
+// loadFromURL downloads rawUrl and loads it as a GameOfLife, picking the
+// loader by file extension first and falling back to a HEAD request's
+// Content-Type when the extension alone doesn't tell us enough.
+func loadFromURL(rawUrl string) (*internal.GameOfLife, error) {
+	if err := fetch.CheckHost(rawUrl); err != nil {
+		return nil, err
+	}
+
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	_, ext := fetch.SplitExt(parsedUrl.Path)
+	loader := loaderForExt(ext)
+
+	if loader == nil {
+		head, err := http.Head(rawUrl)
+		if err != nil {
+			return nil, err
+		}
+		head.Body.Close()
+		loader = loaderForContentType(head.Header.Get("Content-Type"))
+	}
+	if loader == nil {
+		return nil, fmt.Errorf("unsupported resource: %s", rawUrl)
+	}
+
+	resp, err := http.Get(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	tmpDir, err := os.MkdirTemp("cache/", "*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpPath := filepath.Join(tmpDir, "tmp"+ext)
+	fp, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(fp, resp.Body); err != nil {
+		fp.Close()
+		return nil, err
+	}
+	fp.Close()
+
+	return loader(tmpPath)
+}
+
+// handleURLText expands rawUrl (a single image link, or an imgur
+// album/gallery) into every frame it refers to, generates a GIF per
+// frame, and sends the results back as a single media group reply.
+func handleURLText(update tgbotapi.Update, bot *tgbotapi.BotAPI, rawUrl string) {
+	urls, err := fetch.Expand(rawUrl)
+	if err != nil {
+		msg := tgbotapi.NewMessage(update.Message.Chat.ID, fmt.Sprintf("error while expanding url: %v\n", err))
+		msg.ReplyToMessageID = update.Message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	var media []interface{}
+	for _, u := range urls {
+		gol, err := loadFromURL(u)
+		if err != nil {
+			fmt.Printf("failed to load %s: %v\n", u, err)
+			continue
+		}
+		applyChatRule(gol, update.Message.Chat.ID)
+
+		var buffer bytes.Buffer
+		GenerateGIF(gol, &buffer)
+
+		media = append(media, tgbotapi.NewInputMediaDocument(tgbotapi.FileBytes{
+			Name:  "result.gif",
+			Bytes: buffer.Bytes(),
+		}))
+	}
+
+	if len(media) == 0 {
+		msg := tgbotapi.NewMessage(update.Message.Chat.ID, "no loadable images found at that url\n")
+		msg.ReplyToMessageID = update.Message.MessageID
+		bot.Send(msg)
+		return
+	}
+
+	group := tgbotapi.NewMediaGroup(update.Message.Chat.ID, media)
+	if _, err := bot.SendMediaGroup(group); err != nil {
+		fmt.Printf("failed to send media group: %v\n", err)
+	}
+}